@@ -0,0 +1,42 @@
+/*
+ * MinIO Cloud Storage, (C) 2019-2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+// DiskMetrics holds the metrics of a disk, as served up through
+// DiskInfo.Metrics. Every field is populated by xlStorageDiskIDCheck's
+// getMetrics; the map fields hold per storage op values keyed by
+// storageMetric.String(), the others are disk-wide.
+type DiskMetrics struct {
+	APILatencies map[string]string
+	APICalls     map[string]uint64
+
+	// APILatencyPercentiles holds a "p50=...,p95=...,p99=..." rolling
+	// latency summary per storage op, in addition to the EWMA already
+	// captured by APILatencies.
+	APILatencyPercentiles map[string]string
+	// APITimeouts is the lifetime count of per-call deadline timeouts per
+	// storage op.
+	APITimeouts map[string]uint64
+	// CircuitBreakerTripped reports whether this disk's circuit breaker
+	// is currently tripped, quarantining it until a background health
+	// probe re-admits it.
+	CircuitBreakerTripped bool
+	// CancelledCalls is the lifetime count of calls abandoned because the
+	// caller's own context was cancelled, as distinct from calls this
+	// disk itself timed out on.
+	CancelledCalls uint64
+}
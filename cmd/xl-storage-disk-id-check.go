@@ -18,7 +18,9 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"io"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -54,25 +56,170 @@ const (
 	storageMetricWriteMetadata
 	storageMetricReadVersion
 	storageMetricReadAll
+	storageMetricDiskInfo
 
 	// .... add more
 
 	metricLast
 )
 
+const (
+	// diskTimeoutMultiplier is applied to a storage op's rolling EWMA
+	// latency to derive its per-call deadline: max(diskMinTimeout,
+	// diskTimeoutMultiplier*ewma). This lets the deadline track a disk
+	// that is trending slow without waiting on a fixed, global timeout.
+	diskTimeoutMultiplier = 5
+
+	// diskMinTimeout is the floor applied to the adaptive deadline so a
+	// fast disk that has a single noisy sample isn't cut off too early.
+	diskMinTimeout = 1 * time.Second
+
+	// diskMaxTimeout caps the adaptive deadline so a disk that has been
+	// trending slow for a while doesn't end up with multi-minute
+	// deadlines.
+	diskMaxTimeout = 30 * time.Second
+
+	// diskMaxConsecutiveTimeouts is the number of back-to-back per-call
+	// timeouts on a single storage op that trips the circuit breaker,
+	// independent of what the p99 looks like.
+	diskMaxConsecutiveTimeouts = 3
+
+	// diskP99TripThreshold trips the circuit breaker once a storage op's
+	// rolling p99 latency crosses this value.
+	diskP99TripThreshold = 15 * time.Second
+
+	// diskLatencySamples is the size of the rolling window used to derive
+	// the per-metric latency distribution (p50/p95/p99).
+	diskLatencySamples = 128
+
+	// diskHealthProbeInterval is how often a tripped disk is re-probed
+	// with a lightweight operation before being considered for
+	// re-admission.
+	diskHealthProbeInterval = 15 * time.Second
+
+	// diskMaxOrphanedCalls is the number of calls to a single storage op
+	// that the caller has given up on, but whose goroutine is still
+	// blocked against the real syscall, before the breaker trips on that
+	// basis alone. cancellableCall has no way to kill the orphaned
+	// goroutine outright, so this bounds how many can pile up on a
+	// genuinely hung disk: once the breaker trips, checkDiskStale turns
+	// callers away before they ever reach cancellableCall.
+	diskMaxOrphanedCalls = 50
+)
+
+// errDiskSlow is returned by checkDiskStale (and surfaced through
+// IsOnline) once a disk's circuit breaker has tripped due to sustained
+// high latency or repeated per-call timeouts. It is distinct from
+// errDiskNotFound: the disk is still the one we expect, it is simply not
+// answering fast enough to trust right now.
+var errDiskSlow = errors.New("disk is slow, temporarily quarantined")
+
 // Detects change in underlying disk.
 type xlStorageDiskIDCheck struct {
 	storage *xlStorage
 	diskID  string
 
-	apiCalls     [metricLast]uint64
-	apiLatencies [metricLast]ewma.MovingAverage
+	apiCalls          [metricLast]uint64
+	apiLatencies      [metricLast]ewma.MovingAverage
+	apiLatencyDist    [metricLast]*diskLatencyDist
+	apiConsecTimeouts [metricLast]uint32 // atomic, consecutive timeouts per op, reset by any call that does not time out
+	apiTimeouts       [metricLast]uint64 // atomic, lifetime per-call timeout count per op
+	apiCancelled      uint64             // atomic, calls abandoned because the caller's context was cancelled
+	apiOrphaned       [metricLast]uint64 // atomic, per-op count of goroutines still running after the caller gave up
+
+	breaker diskCircuitBreaker
+
+	probeMu     sync.Mutex
+	probeCancel context.CancelFunc
+}
+
+// diskLatencyDist tracks a rolling window of per-op latencies so p50/p95/p99
+// can be derived on demand. It is intentionally separate from the
+// longer-memory EWMA used for the adaptive deadline: the EWMA smooths out
+// noise for timeout calculation, the distribution exists to answer "how bad
+// does the tail look right now".
+type diskLatencyDist struct {
+	mu      sync.Mutex
+	samples [diskLatencySamples]time.Duration
+	next    int
+	filled  bool
+}
+
+func (d *diskLatencyDist) add(v time.Duration) {
+	d.mu.Lock()
+	d.samples[d.next] = v
+	d.next++
+	if d.next == diskLatencySamples {
+		d.next = 0
+		d.filled = true
+	}
+	d.mu.Unlock()
 }
 
+// percentiles returns the p50/p95/p99 of the current window. It is zero
+// valued until at least one sample has been recorded.
+func (d *diskLatencyDist) percentiles() (p50, p95, p99 time.Duration) {
+	d.mu.Lock()
+	n := d.next
+	if d.filled {
+		n = diskLatencySamples
+	}
+	if n == 0 {
+		d.mu.Unlock()
+		return 0, 0, 0
+	}
+	samples := make([]time.Duration, n)
+	copy(samples, d.samples[:n])
+	d.mu.Unlock()
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return samples[percentileIndex(n, 50)], samples[percentileIndex(n, 95)], samples[percentileIndex(n, 99)]
+}
+
+// percentileIndex returns the index into a sorted, n-length sample slice
+// corresponding to the given percentile (0-100).
+func percentileIndex(n, pct int) int {
+	idx := (n * pct) / 100
+	if idx >= n {
+		idx = n - 1
+	}
+	return idx
+}
+
+// diskCircuitBreaker gates a disk that has been observed to be slow or
+// hung. Once tripped, IsOnline and checkDiskStale both report the disk as
+// unusable until a background probe goroutine observes a fast, healthy
+// response and resets it.
+type diskCircuitBreaker struct {
+	tripped int32 // atomic
+}
+
+func (b *diskCircuitBreaker) isTripped() bool {
+	return atomic.LoadInt32(&b.tripped) == 1
+}
+
+// trip reports whether this call actually flipped the breaker, so callers
+// don't start duplicate probe goroutines.
+func (b *diskCircuitBreaker) trip() bool {
+	return atomic.CompareAndSwapInt32(&b.tripped, 0, 1)
+}
+
+func (b *diskCircuitBreaker) reset() {
+	atomic.StoreInt32(&b.tripped, 0)
+}
+
+// getMetrics assembles this disk's metrics snapshot, including the latency
+// distribution and breaker state added alongside DiskMetrics'
+// APILatencyPercentiles, APITimeouts, CircuitBreakerTripped and
+// CancelledCalls fields (see storage-interface.go).
 func (p *xlStorageDiskIDCheck) getMetrics() DiskMetrics {
 	diskMetric := DiskMetrics{
-		APILatencies: make(map[string]string),
-		APICalls:     make(map[string]uint64),
+		APILatencies:          make(map[string]string),
+		APICalls:              make(map[string]uint64),
+		APILatencyPercentiles: make(map[string]string),
+		APITimeouts:           make(map[string]uint64),
+		CircuitBreakerTripped: p.breaker.isTripped(),
+		CancelledCalls:        atomic.LoadUint64(&p.apiCancelled),
 	}
 	for i, v := range p.apiLatencies {
 		diskMetric.APILatencies[storageMetric(i).String()] = time.Duration(v.Value()).String()
@@ -80,6 +227,11 @@ func (p *xlStorageDiskIDCheck) getMetrics() DiskMetrics {
 	for i := range p.apiCalls {
 		diskMetric.APICalls[storageMetric(i).String()] = atomic.LoadUint64(&p.apiCalls[i])
 	}
+	for i, d := range p.apiLatencyDist {
+		p50, p95, p99 := d.percentiles()
+		diskMetric.APILatencyPercentiles[storageMetric(i).String()] = "p50=" + p50.String() + ",p95=" + p95.String() + ",p99=" + p99.String()
+		diskMetric.APITimeouts[storageMetric(i).String()] = atomic.LoadUint64(&p.apiTimeouts[i])
+	}
 	return diskMetric
 }
 
@@ -115,6 +267,7 @@ func newXLStorageDiskIDCheck(storage *xlStorage) *xlStorageDiskIDCheck {
 		xl.apiLatencies[i] = &lockedSimpleEWMA{
 			SimpleEWMA: new(ewma.SimpleEWMA),
 		}
+		xl.apiLatencyDist[i] = &diskLatencyDist{}
 	}
 	return &xl
 }
@@ -124,6 +277,9 @@ func (p *xlStorageDiskIDCheck) String() string {
 }
 
 func (p *xlStorageDiskIDCheck) IsOnline() bool {
+	if p.breaker.isTripped() {
+		return false
+	}
 	storedDiskID, err := p.storage.GetDiskID()
 	if err != nil {
 		return false
@@ -157,6 +313,12 @@ func (p *xlStorageDiskIDCheck) NSScanner(ctx context.Context, cache dataUsageCac
 	if err := p.checkDiskStale(); err != nil {
 		return dataUsageCache{}, err
 	}
+
+	// NSScanner walks this disk's entire namespace and can legitimately
+	// run for minutes; it has no storageMetric slot and so no EWMA to
+	// derive an adaptive deadline from. Left intentionally unwrapped by
+	// deadlineCtx/cancellableCall rather than bound to a made-up timeout;
+	// a caller that needs this bounded supplies its own ctx deadline.
 	return p.storage.NSScanner(ctx, cache)
 }
 
@@ -169,6 +331,7 @@ func (p *xlStorageDiskIDCheck) SetDiskLoc(poolIdx, setIdx, diskIdx int) {
 }
 
 func (p *xlStorageDiskIDCheck) Close() error {
+	p.stopHealthProbe()
 	return p.storage.Close()
 }
 
@@ -181,6 +344,9 @@ func (p *xlStorageDiskIDCheck) SetDiskID(id string) {
 }
 
 func (p *xlStorageDiskIDCheck) checkDiskStale() error {
+	if p.breaker.isTripped() {
+		return errDiskSlow
+	}
 	if p.diskID == "" {
 		// For empty disk-id we allow the call as the server might be
 		// coming up and trying to read format.json or create format.json
@@ -198,14 +364,238 @@ func (p *xlStorageDiskIDCheck) checkDiskStale() error {
 	return errDiskNotFound
 }
 
+// adaptiveTimeout returns the per-call deadline for s:
+// max(diskMinTimeout, min(diskMaxTimeout, diskTimeoutMultiplier*ewma)).
+func (p *xlStorageDiskIDCheck) adaptiveTimeout(s storageMetric) time.Duration {
+	timeout := time.Duration(p.apiLatencies[s].Value()) * diskTimeoutMultiplier
+	if timeout < diskMinTimeout {
+		timeout = diskMinTimeout
+	} else if timeout > diskMaxTimeout {
+		timeout = diskMaxTimeout
+	}
+	return timeout
+}
+
+// deadlineCtx derives a context carrying adaptiveTimeout's per-call
+// deadline for the given op. On its own this bounds nothing: the real
+// xlStorage syscalls underneath don't watch ctx, so the deadline only has
+// teeth when the call is also run through cancellableCall, which is what
+// actually makes the caller return once it elapses. The returned timeout
+// is the exact value dctx was given; callers must pass it on to
+// recordIfCancelled rather than recomputing adaptiveTimeout(s) later, since
+// s's EWMA can drift while the call is in flight and a freshly recomputed
+// value would no longer match the budget this call actually got.
+func (p *xlStorageDiskIDCheck) deadlineCtx(ctx context.Context, s storageMetric) (context.Context, context.CancelFunc, time.Duration) {
+	timeout := p.adaptiveTimeout(s)
+	dctx, cancel := context.WithTimeout(ctx, timeout)
+	return dctx, cancel, timeout
+}
+
+// cancellableCall runs fn in its own goroutine and returns as soon as
+// either fn completes or ctx is done. If ctx wins the race, ctx.Err() is
+// returned immediately and fn is left to drain in the background; fn is
+// expected to notice the cancellation on its own (e.g. via a closed file
+// descriptor) and return. This lets a caller give up on a stuck syscall
+// without waiting for it, at the cost of leaking the goroutine until the
+// underlying call eventually unblocks.
+func cancellableCall(ctx context.Context, fn func() error) error {
+	doneCh := make(chan error, 1)
+	go func() { doneCh <- fn() }()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-doneCh:
+		return err
+	}
+}
+
+// watchdogReadCloser closes its underlying ReadCloser as soon as ctx is
+// done, interrupting a Read that is blocked on a stuck disk instead of
+// leaving it to the caller to notice the context was cancelled.
+type watchdogReadCloser struct {
+	io.ReadCloser
+	stop func() error
+}
+
+func (w *watchdogReadCloser) Close() error {
+	return w.stop()
+}
+
+func withReadCloserWatchdog(ctx context.Context, rc io.ReadCloser) io.ReadCloser {
+	done := make(chan struct{})
+	var once sync.Once
+	var closeErr error
+	closeFn := func() error {
+		once.Do(func() {
+			closeErr = rc.Close()
+			close(done)
+		})
+		return closeErr
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			closeFn()
+		case <-done:
+		}
+	}()
+	return &watchdogReadCloser{
+		ReadCloser: rc,
+		stop:       closeFn,
+	}
+}
+
+// cancelReader makes reader's Read return ctx.Err() once ctx is done,
+// instead of running to completion. This is what lets CreateFile's upload
+// loop inside xlStorage notice a client disconnect without xlStorage
+// itself watching ctx: it only takes effect between Read calls, so a Read
+// already blocked won't be interrupted by it, but it stops the loop from
+// asking the reader for any more data once the caller has given up.
+type cancelReader struct {
+	io.Reader
+	ctx context.Context
+}
+
+func (c *cancelReader) Read(p []byte) (int, error) {
+	select {
+	case <-c.ctx.Done():
+		return 0, c.ctx.Err()
+	default:
+	}
+	return c.Reader.Read(p)
+}
+
+func withReaderCancel(ctx context.Context, r io.Reader) io.Reader {
+	return &cancelReader{Reader: r, ctx: ctx}
+}
+
+// recordIfCancelled bumps the cancelled-call counter when the caller's own
+// ctx (not our derived per-call deadline) is what ended the request, so
+// operators can tell a client that gave up apart from a disk that simply
+// took too long. start is when this attempt began and timeout is the exact
+// adaptive deadline deadlineCtx gave dctx for this call — not a value
+// recomputed from s's current EWMA, which can have drifted since the call
+// started and would otherwise judge this call against a budget it was
+// never actually given. A call the caller abandoned well within that
+// budget is ordinary client behaviour (a browser tab closing mid-download,
+// a client retrying elsewhere) and says nothing about the disk, so it only
+// counts against apiCancelled. Only a cancellation on a call that had
+// already run past its own timeout is treated as an orphaned goroutine
+// still blocked against the real syscall; once diskMaxOrphanedCalls of
+// those pile up for s, that's evidence the disk itself is hung and trips
+// the breaker, which stops checkDiskStale from letting any more calls
+// reach cancellableCall and bounds how many goroutines can accumulate.
+func (p *xlStorageDiskIDCheck) recordIfCancelled(ctx context.Context, s storageMetric, start time.Time, timeout time.Duration) {
+	if ctx.Err() != context.Canceled {
+		return
+	}
+	atomic.AddUint64(&p.apiCancelled, 1)
+	if time.Since(start) < timeout {
+		return
+	}
+	if atomic.AddUint64(&p.apiOrphaned[s], 1) >= diskMaxOrphanedCalls {
+		p.tripBreaker()
+	}
+}
+
+// tripBreaker trips the circuit breaker for this disk, if it isn't already
+// tripped, and kicks off the background probe that will re-admit the disk
+// once it answers a lightweight call quickly again.
+func (p *xlStorageDiskIDCheck) tripBreaker() {
+	if p.breaker.trip() {
+		p.startHealthProbe()
+	}
+}
+
+// resetBreaker resets the breaker and the per-op orphan counts that feed
+// it. Without clearing apiOrphaned, an op that once crossed
+// diskMaxOrphanedCalls would stay pinned at or above the threshold forever,
+// so the very next client-side cancellation for that op would re-trip an
+// otherwise healthy disk.
+func (p *xlStorageDiskIDCheck) resetBreaker() {
+	p.breaker.reset()
+	for i := range p.apiOrphaned {
+		atomic.StoreUint64(&p.apiOrphaned[i], 0)
+	}
+}
+
+// startHealthProbe runs a lightweight GetDiskID call on a ticker until the
+// disk responds within diskMinTimeout, at which point the breaker is reset
+// and the probe exits. Each probe races GetDiskID against its own bounded
+// context the same way cancellableCall does, so a disk that is genuinely
+// hung doesn't park this goroutine forever: the ticker keeps retrying, and
+// stopHealthProbe's cancel actually unblocks the goroutine instead of
+// being ignored while it waits on a blocking syscall.
+func (p *xlStorageDiskIDCheck) startHealthProbe() {
+	p.probeMu.Lock()
+	defer p.probeMu.Unlock()
+	if p.probeCancel != nil {
+		// a probe is already in flight for this disk
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.probeCancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(diskHealthProbeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				probeCtx, probeCancel := context.WithTimeout(ctx, diskMinTimeout)
+				start := time.Now()
+				err := cancellableCall(probeCtx, func() error {
+					_, err := p.storage.GetDiskID()
+					return err
+				})
+				probeCancel()
+				if err == nil && time.Since(start) < diskMinTimeout {
+					p.resetBreaker()
+					p.probeMu.Lock()
+					p.probeCancel = nil
+					p.probeMu.Unlock()
+					return
+				}
+			}
+		}
+	}()
+}
+
+func (p *xlStorageDiskIDCheck) stopHealthProbe() {
+	p.probeMu.Lock()
+	defer p.probeMu.Unlock()
+	if p.probeCancel != nil {
+		p.probeCancel()
+		p.probeCancel = nil
+	}
+}
+
 func (p *xlStorageDiskIDCheck) DiskInfo(ctx context.Context) (info DiskInfo, err error) {
+	defer p.updateStorageMetrics(ctx, storageMetricDiskInfo, time.Now(), &err)
+
 	select {
 	case <-ctx.Done():
 		return DiskInfo{}, ctx.Err()
 	default:
 	}
 
-	info, err = p.storage.DiskInfo(ctx)
+	if p.breaker.isTripped() {
+		return DiskInfo{}, errDiskSlow
+	}
+
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricDiskInfo)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		var cerr error
+		info, cerr = p.storage.DiskInfo(dctx)
+		return cerr
+	})
+	p.recordIfCancelled(ctx, storageMetricDiskInfo, start, timeout)
 	if err != nil {
 		return info, err
 	}
@@ -222,7 +612,7 @@ func (p *xlStorageDiskIDCheck) DiskInfo(ctx context.Context) (info DiskInfo, err
 }
 
 func (p *xlStorageDiskIDCheck) MakeVolBulk(ctx context.Context, volumes ...string) (err error) {
-	defer p.updateStorageMetrics(storageMetricMakeVolBulk)()
+	defer p.updateStorageMetrics(ctx, storageMetricMakeVolBulk, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -233,11 +623,20 @@ func (p *xlStorageDiskIDCheck) MakeVolBulk(ctx context.Context, volumes ...strin
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
-	return p.storage.MakeVolBulk(ctx, volumes...)
+
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricMakeVolBulk)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.MakeVolBulk(dctx, volumes...)
+	})
+	p.recordIfCancelled(ctx, storageMetricMakeVolBulk, start, timeout)
+	return err
 }
 
 func (p *xlStorageDiskIDCheck) MakeVol(ctx context.Context, volume string) (err error) {
-	defer p.updateStorageMetrics(storageMetricMakeVol)()
+	defer p.updateStorageMetrics(ctx, storageMetricMakeVol, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -248,11 +647,20 @@ func (p *xlStorageDiskIDCheck) MakeVol(ctx context.Context, volume string) (err
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
-	return p.storage.MakeVol(ctx, volume)
+
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricMakeVol)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.MakeVol(dctx, volume)
+	})
+	p.recordIfCancelled(ctx, storageMetricMakeVol, start, timeout)
+	return err
 }
 
-func (p *xlStorageDiskIDCheck) ListVols(ctx context.Context) ([]VolInfo, error) {
-	defer p.updateStorageMetrics(storageMetricListVols)()
+func (p *xlStorageDiskIDCheck) ListVols(ctx context.Context) (vols []VolInfo, err error) {
+	defer p.updateStorageMetrics(ctx, storageMetricListVols, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -260,14 +668,25 @@ func (p *xlStorageDiskIDCheck) ListVols(ctx context.Context) ([]VolInfo, error)
 	default:
 	}
 
-	if err := p.checkDiskStale(); err != nil {
+	if err = p.checkDiskStale(); err != nil {
 		return nil, err
 	}
-	return p.storage.ListVols(ctx)
+
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricListVols)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		var cerr error
+		vols, cerr = p.storage.ListVols(dctx)
+		return cerr
+	})
+	p.recordIfCancelled(ctx, storageMetricListVols, start, timeout)
+	return vols, err
 }
 
 func (p *xlStorageDiskIDCheck) StatVol(ctx context.Context, volume string) (vol VolInfo, err error) {
-	defer p.updateStorageMetrics(storageMetricStatVol)()
+	defer p.updateStorageMetrics(ctx, storageMetricStatVol, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -278,11 +697,22 @@ func (p *xlStorageDiskIDCheck) StatVol(ctx context.Context, volume string) (vol
 	if err = p.checkDiskStale(); err != nil {
 		return vol, err
 	}
-	return p.storage.StatVol(ctx, volume)
+
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricStatVol)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		var cerr error
+		vol, cerr = p.storage.StatVol(dctx, volume)
+		return cerr
+	})
+	p.recordIfCancelled(ctx, storageMetricStatVol, start, timeout)
+	return vol, err
 }
 
 func (p *xlStorageDiskIDCheck) DeleteVol(ctx context.Context, volume string, forceDelete bool) (err error) {
-	defer p.updateStorageMetrics(storageMetricDeleteVol)()
+	defer p.updateStorageMetrics(ctx, storageMetricDeleteVol, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -293,11 +723,20 @@ func (p *xlStorageDiskIDCheck) DeleteVol(ctx context.Context, volume string, for
 	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
-	return p.storage.DeleteVol(ctx, volume, forceDelete)
+
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricDeleteVol)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.DeleteVol(dctx, volume, forceDelete)
+	})
+	p.recordIfCancelled(ctx, storageMetricDeleteVol, start, timeout)
+	return err
 }
 
-func (p *xlStorageDiskIDCheck) ListDir(ctx context.Context, volume, dirPath string, count int) ([]string, error) {
-	defer p.updateStorageMetrics(storageMetricListDir)()
+func (p *xlStorageDiskIDCheck) ListDir(ctx context.Context, volume, dirPath string, count int) (entries []string, err error) {
+	defer p.updateStorageMetrics(ctx, storageMetricListDir, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -305,15 +744,31 @@ func (p *xlStorageDiskIDCheck) ListDir(ctx context.Context, volume, dirPath stri
 	default:
 	}
 
-	if err := p.checkDiskStale(); err != nil {
+	if err = p.checkDiskStale(); err != nil {
 		return nil, err
 	}
 
-	return p.storage.ListDir(ctx, volume, dirPath, count)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricListDir)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		var cerr error
+		entries, cerr = p.storage.ListDir(dctx, volume, dirPath, count)
+		return cerr
+	})
+	p.recordIfCancelled(ctx, storageMetricListDir, start, timeout)
+	return entries, err
 }
 
+// ReadFile hands xlStorage a single pre-sized buf rather than a stream, so
+// unlike ReadFileStream there is no fd or reader at this layer to close or
+// refuse once ctx is cancelled: the underlying pread runs to completion
+// regardless, and cancellableCall below only stops this wrapper from
+// waiting on it. Making the read itself interruptible needs a cancellable
+// primitive inside xlStorage, not another workaround bolted on here.
 func (p *xlStorageDiskIDCheck) ReadFile(ctx context.Context, volume string, path string, offset int64, buf []byte, verifier *BitrotVerifier) (n int64, err error) {
-	defer p.updateStorageMetrics(storageMetricReadFile)()
+	defer p.updateStorageMetrics(ctx, storageMetricReadFile, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -321,15 +776,28 @@ func (p *xlStorageDiskIDCheck) ReadFile(ctx context.Context, volume string, path
 	default:
 	}
 
-	if err := p.checkDiskStale(); err != nil {
+	if err = p.checkDiskStale(); err != nil {
 		return 0, err
 	}
 
-	return p.storage.ReadFile(ctx, volume, path, offset, buf, verifier)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricReadFile)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		var cerr error
+		n, cerr = p.storage.ReadFile(dctx, volume, path, offset, buf, verifier)
+		return cerr
+	})
+	p.recordIfCancelled(ctx, storageMetricReadFile, start, timeout)
+	return n, err
 }
 
+// AppendFile, like ReadFile, hands xlStorage an already-complete buf
+// instead of a stream; see the note on ReadFile for why that leaves the
+// underlying pwrite uninterruptible from this layer.
 func (p *xlStorageDiskIDCheck) AppendFile(ctx context.Context, volume string, path string, buf []byte) (err error) {
-	defer p.updateStorageMetrics(storageMetricAppendFile)()
+	defer p.updateStorageMetrics(ctx, storageMetricAppendFile, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -341,11 +809,19 @@ func (p *xlStorageDiskIDCheck) AppendFile(ctx context.Context, volume string, pa
 		return err
 	}
 
-	return p.storage.AppendFile(ctx, volume, path, buf)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricAppendFile)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.AppendFile(dctx, volume, path, buf)
+	})
+	p.recordIfCancelled(ctx, storageMetricAppendFile, start, timeout)
+	return err
 }
 
-func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path string, size int64, reader io.Reader) error {
-	defer p.updateStorageMetrics(storageMetricCreateFile)()
+func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path string, size int64, reader io.Reader) (err error) {
+	defer p.updateStorageMetrics(ctx, storageMetricCreateFile, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -353,15 +829,23 @@ func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path stri
 	default:
 	}
 
-	if err := p.checkDiskStale(); err != nil {
+	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.CreateFile(ctx, volume, path, size, reader)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricCreateFile)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.CreateFile(dctx, volume, path, size, withReaderCancel(dctx, reader))
+	})
+	p.recordIfCancelled(ctx, storageMetricCreateFile, start, timeout)
+	return err
 }
 
-func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (io.ReadCloser, error) {
-	defer p.updateStorageMetrics(storageMetricReadFileStream)()
+func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (rc io.ReadCloser, err error) {
+	defer p.updateStorageMetrics(ctx, storageMetricReadFileStream, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -369,15 +853,33 @@ func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path
 	default:
 	}
 
-	if err := p.checkDiskStale(); err != nil {
+	if err = p.checkDiskStale(); err != nil {
 		return nil, err
 	}
 
-	return p.storage.ReadFileStream(ctx, volume, path, offset, length)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricReadFileStream)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		var cerr error
+		rc, cerr = p.storage.ReadFileStream(dctx, volume, path, offset, length)
+		return cerr
+	})
+	p.recordIfCancelled(ctx, storageMetricReadFileStream, start, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stream's remaining lifetime is tied to the caller's ctx, not the
+	// short-lived deadline used to bound opening it: cancelling ctx now
+	// interrupts an in-flight Read instead of leaving it to the caller to
+	// notice on its own.
+	return withReadCloserWatchdog(ctx, rc), nil
 }
 
-func (p *xlStorageDiskIDCheck) RenameFile(ctx context.Context, srcVolume, srcPath, dstVolume, dstPath string) error {
-	defer p.updateStorageMetrics(storageMetricRenameFile)()
+func (p *xlStorageDiskIDCheck) RenameFile(ctx context.Context, srcVolume, srcPath, dstVolume, dstPath string) (err error) {
+	defer p.updateStorageMetrics(ctx, storageMetricRenameFile, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -385,15 +887,23 @@ func (p *xlStorageDiskIDCheck) RenameFile(ctx context.Context, srcVolume, srcPat
 	default:
 	}
 
-	if err := p.checkDiskStale(); err != nil {
+	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.RenameFile(ctx, srcVolume, srcPath, dstVolume, dstPath)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricRenameFile)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.RenameFile(dctx, srcVolume, srcPath, dstVolume, dstPath)
+	})
+	p.recordIfCancelled(ctx, storageMetricRenameFile, start, timeout)
+	return err
 }
 
-func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPath, dataDir, dstVolume, dstPath string) error {
-	defer p.updateStorageMetrics(storageMetricRenameData)()
+func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPath, dataDir, dstVolume, dstPath string) (err error) {
+	defer p.updateStorageMetrics(ctx, storageMetricRenameData, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -401,15 +911,23 @@ func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPat
 	default:
 	}
 
-	if err := p.checkDiskStale(); err != nil {
+	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.RenameData(ctx, srcVolume, srcPath, dataDir, dstVolume, dstPath)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricRenameData)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.RenameData(dctx, srcVolume, srcPath, dataDir, dstVolume, dstPath)
+	})
+	p.recordIfCancelled(ctx, storageMetricRenameData, start, timeout)
+	return err
 }
 
 func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (err error) {
-	defer p.updateStorageMetrics(storageMetricCheckParts)()
+	defer p.updateStorageMetrics(ctx, storageMetricCheckParts, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -421,11 +939,19 @@ func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, pa
 		return err
 	}
 
-	return p.storage.CheckParts(ctx, volume, path, fi)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricCheckParts)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.CheckParts(dctx, volume, path, fi)
+	})
+	p.recordIfCancelled(ctx, storageMetricCheckParts, start, timeout)
+	return err
 }
 
 func (p *xlStorageDiskIDCheck) CheckFile(ctx context.Context, volume string, path string) (err error) {
-	defer p.updateStorageMetrics(storageMetricCheckFile)()
+	defer p.updateStorageMetrics(ctx, storageMetricCheckFile, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -437,11 +963,19 @@ func (p *xlStorageDiskIDCheck) CheckFile(ctx context.Context, volume string, pat
 		return err
 	}
 
-	return p.storage.CheckFile(ctx, volume, path)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricCheckFile)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.CheckFile(dctx, volume, path)
+	})
+	p.recordIfCancelled(ctx, storageMetricCheckFile, start, timeout)
+	return err
 }
 
 func (p *xlStorageDiskIDCheck) Delete(ctx context.Context, volume string, path string, recursive bool) (err error) {
-	defer p.updateStorageMetrics(storageMetricDelete)()
+	defer p.updateStorageMetrics(ctx, storageMetricDelete, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -453,34 +987,75 @@ func (p *xlStorageDiskIDCheck) Delete(ctx context.Context, volume string, path s
 		return err
 	}
 
-	return p.storage.Delete(ctx, volume, path, recursive)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricDelete)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.Delete(dctx, volume, path, recursive)
+	})
+	p.recordIfCancelled(ctx, storageMetricDelete, start, timeout)
+	return err
 }
 
 func (p *xlStorageDiskIDCheck) DeleteVersions(ctx context.Context, volume string, versions []FileInfo) (errs []error) {
-	defer p.updateStorageMetrics(storageMetricDeleteVersions)()
+	// errp is DeleteVersions' stand-in for a single representative error:
+	// updateStorageMetrics only needs to know whether this call hit the
+	// per-call deadline, and cerr below is exactly that signal, so route
+	// it through the same &error path every other wrapped call uses
+	// instead of passing nil and silently losing the timeout.
+	var errp error
+	defer p.updateStorageMetrics(ctx, storageMetricDeleteVersions, time.Now(), &errp)
 
 	select {
 	case <-ctx.Done():
+		errp = ctx.Err()
 		errs = make([]error, len(versions))
 		for i := range errs {
-			errs[i] = ctx.Err()
+			errs[i] = errp
 		}
 		return errs
 	default:
 	}
 
 	if err := p.checkDiskStale(); err != nil {
+		errp = err
 		errs = make([]error, len(versions))
 		for i := range errs {
 			errs[i] = err
 		}
 		return errs
 	}
-	return p.storage.DeleteVersions(ctx, volume, versions)
+
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricDeleteVersions)
+	defer cancel()
+	start := time.Now()
+
+	// result is local to the closure rather than writing directly to the
+	// named return: if dctx's deadline wins the race in cancellableCall,
+	// the orphaned goroutine assigns to it after this function has
+	// already returned (and, on a cancellation, after the caller below
+	// has already built its own errs slice), so sharing errs between the
+	// two would be a data race.
+	var result []error
+	cerr := cancellableCall(dctx, func() error {
+		result = p.storage.DeleteVersions(dctx, volume, versions)
+		return nil
+	})
+	errp = cerr
+	p.recordIfCancelled(ctx, storageMetricDeleteVersions, start, timeout)
+	if cerr != nil {
+		errs = make([]error, len(versions))
+		for i := range errs {
+			errs[i] = cerr
+		}
+		return errs
+	}
+	return result
 }
 
-func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) error {
-	defer p.updateStorageMetrics(storageMetricVerifyFile)()
+func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (err error) {
+	defer p.updateStorageMetrics(ctx, storageMetricVerifyFile, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -488,15 +1063,26 @@ func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path stri
 	default:
 	}
 
-	if err := p.checkDiskStale(); err != nil {
+	if err = p.checkDiskStale(); err != nil {
 		return err
 	}
 
-	return p.storage.VerifyFile(ctx, volume, path, fi)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricVerifyFile)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.VerifyFile(dctx, volume, path, fi)
+	})
+	p.recordIfCancelled(ctx, storageMetricVerifyFile, start, timeout)
+	return err
 }
 
+// WriteAll, like ReadFile, hands xlStorage an already-complete b instead of
+// a stream; see the note on ReadFile for why that leaves the underlying
+// pwrite uninterruptible from this layer.
 func (p *xlStorageDiskIDCheck) WriteAll(ctx context.Context, volume string, path string, b []byte) (err error) {
-	defer p.updateStorageMetrics(storageMetricWriteAll)()
+	defer p.updateStorageMetrics(ctx, storageMetricWriteAll, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -508,11 +1094,19 @@ func (p *xlStorageDiskIDCheck) WriteAll(ctx context.Context, volume string, path
 		return err
 	}
 
-	return p.storage.WriteAll(ctx, volume, path, b)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricWriteAll)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.WriteAll(dctx, volume, path, b)
+	})
+	p.recordIfCancelled(ctx, storageMetricWriteAll, start, timeout)
+	return err
 }
 
 func (p *xlStorageDiskIDCheck) DeleteVersion(ctx context.Context, volume, path string, fi FileInfo, forceDelMarker bool) (err error) {
-	defer p.updateStorageMetrics(storageMetricDeleteVersion)()
+	defer p.updateStorageMetrics(ctx, storageMetricDeleteVersion, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -524,11 +1118,19 @@ func (p *xlStorageDiskIDCheck) DeleteVersion(ctx context.Context, volume, path s
 		return err
 	}
 
-	return p.storage.DeleteVersion(ctx, volume, path, fi, forceDelMarker)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricDeleteVersion)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.DeleteVersion(dctx, volume, path, fi, forceDelMarker)
+	})
+	p.recordIfCancelled(ctx, storageMetricDeleteVersion, start, timeout)
+	return err
 }
 
 func (p *xlStorageDiskIDCheck) WriteMetadata(ctx context.Context, volume, path string, fi FileInfo) (err error) {
-	defer p.updateStorageMetrics(storageMetricWriteMetadata)()
+	defer p.updateStorageMetrics(ctx, storageMetricWriteMetadata, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -540,11 +1142,19 @@ func (p *xlStorageDiskIDCheck) WriteMetadata(ctx context.Context, volume, path s
 		return err
 	}
 
-	return p.storage.WriteMetadata(ctx, volume, path, fi)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricWriteMetadata)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		return p.storage.WriteMetadata(dctx, volume, path, fi)
+	})
+	p.recordIfCancelled(ctx, storageMetricWriteMetadata, start, timeout)
+	return err
 }
 
 func (p *xlStorageDiskIDCheck) ReadVersion(ctx context.Context, volume, path, versionID string, readData bool) (fi FileInfo, err error) {
-	defer p.updateStorageMetrics(storageMetricReadVersion)()
+	defer p.updateStorageMetrics(ctx, storageMetricReadVersion, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -556,11 +1166,21 @@ func (p *xlStorageDiskIDCheck) ReadVersion(ctx context.Context, volume, path, ve
 		return fi, err
 	}
 
-	return p.storage.ReadVersion(ctx, volume, path, versionID, readData)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricReadVersion)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		var cerr error
+		fi, cerr = p.storage.ReadVersion(dctx, volume, path, versionID, readData)
+		return cerr
+	})
+	p.recordIfCancelled(ctx, storageMetricReadVersion, start, timeout)
+	return fi, err
 }
 
 func (p *xlStorageDiskIDCheck) ReadAll(ctx context.Context, volume string, path string) (buf []byte, err error) {
-	defer p.updateStorageMetrics(storageMetricReadAll)()
+	defer p.updateStorageMetrics(ctx, storageMetricReadAll, time.Now(), &err)
 
 	select {
 	case <-ctx.Done():
@@ -572,14 +1192,57 @@ func (p *xlStorageDiskIDCheck) ReadAll(ctx context.Context, volume string, path
 		return nil, err
 	}
 
-	return p.storage.ReadAll(ctx, volume, path)
+	dctx, cancel, timeout := p.deadlineCtx(ctx, storageMetricReadAll)
+	defer cancel()
+	start := time.Now()
+
+	err = cancellableCall(dctx, func() error {
+		var cerr error
+		buf, cerr = p.storage.ReadAll(dctx, volume, path)
+		return cerr
+	})
+	p.recordIfCancelled(ctx, storageMetricReadAll, start, timeout)
+	return buf, err
 }
 
-// Update storage metrics
-func (p *xlStorageDiskIDCheck) updateStorageMetrics(s storageMetric) func() {
-	startTime := time.Now()
-	return func() {
-		atomic.AddUint64(&p.apiCalls[s], 1)
-		p.apiLatencies[s].Add(float64(time.Since(startTime)))
+// updateStorageMetrics records the call count and latency for s, feeds the
+// rolling latency distribution used for p50/p95/p99, and trips the circuit
+// breaker if either the per-op p99 or the consecutive-timeout count for s
+// crosses its threshold. errp may be nil for callers that don't have a
+// representative error to report. ctx is the caller's original, outer
+// context (not the adaptive-deadline dctx the call actually ran under):
+// when the caller's own ctx carries a deadline tighter than our adaptive
+// one, context.WithTimeout(ctx, ...) returns a pass-through for dctx, so a
+// DeadlineExceeded here can mean the caller's own budget expired rather
+// than this disk being slow, and ctx is what lets us tell those apart.
+func (p *xlStorageDiskIDCheck) updateStorageMetrics(ctx context.Context, s storageMetric, startTime time.Time, errp *error) {
+	atomic.AddUint64(&p.apiCalls[s], 1)
+	elapsed := time.Since(startTime)
+	p.apiLatencies[s].Add(float64(elapsed))
+	p.apiLatencyDist[s].add(elapsed)
+
+	var err error
+	if errp != nil {
+		err = *errp
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		if ctx.Err() != context.DeadlineExceeded {
+			// our own adaptive deadline was what fired, not an inherited
+			// one, so this is real evidence about this disk.
+			atomic.AddUint64(&p.apiTimeouts[s], 1)
+			if atomic.AddUint32(&p.apiConsecTimeouts[s], 1) >= diskMaxConsecutiveTimeouts {
+				p.tripBreaker()
+			}
+		}
+		// else: the caller's own ctx deadline fired and simply propagated
+		// through dctx; that says nothing about this disk, so leave
+		// apiConsecTimeouts untouched rather than crediting or blaming it.
+	} else {
+		atomic.StoreUint32(&p.apiConsecTimeouts[s], 0)
+	}
+
+	if _, _, p99 := p.apiLatencyDist[s].percentiles(); p99 >= diskP99TripThreshold {
+		p.tripBreaker()
 	}
 }
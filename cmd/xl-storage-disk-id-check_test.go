@@ -0,0 +1,283 @@
+/*
+ * MinIO Cloud Storage, (C) 2019-2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiskLatencyDistPercentilesEmpty(t *testing.T) {
+	d := &diskLatencyDist{}
+	p50, p95, p99 := d.percentiles()
+	if p50 != 0 || p95 != 0 || p99 != 0 {
+		t.Fatalf("expected zero percentiles before any sample, got p50=%v p95=%v p99=%v", p50, p95, p99)
+	}
+}
+
+func TestDiskLatencyDistPercentiles(t *testing.T) {
+	d := &diskLatencyDist{}
+	for i := 1; i <= 100; i++ {
+		d.add(time.Duration(i) * time.Millisecond)
+	}
+	// percentileIndex maps pct directly onto the sorted sample index
+	// (idx = n*pct/100), so for 100 ascending 1ms..100ms samples the
+	// p-th percentile lands on the (p+1)-th smallest value.
+	p50, p95, p99 := d.percentiles()
+	if p50 != 51*time.Millisecond {
+		t.Errorf("p50 = %v, want 51ms", p50)
+	}
+	if p95 != 96*time.Millisecond {
+		t.Errorf("p95 = %v, want 96ms", p95)
+	}
+	if p99 != 100*time.Millisecond {
+		t.Errorf("p99 = %v, want 100ms", p99)
+	}
+}
+
+func TestDiskLatencyDistRollingWindow(t *testing.T) {
+	d := &diskLatencyDist{}
+	// Fill the window once with a low value, then overwrite it entirely
+	// with a high value; percentiles should reflect only the latest
+	// diskLatencySamples entries, not the stale ones.
+	for i := 0; i < diskLatencySamples; i++ {
+		d.add(1 * time.Millisecond)
+	}
+	for i := 0; i < diskLatencySamples; i++ {
+		d.add(100 * time.Millisecond)
+	}
+	p50, _, p99 := d.percentiles()
+	if p50 != 100*time.Millisecond || p99 != 100*time.Millisecond {
+		t.Fatalf("expected stale samples to be fully evicted, got p50=%v p99=%v", p50, p99)
+	}
+}
+
+func TestDiskCircuitBreakerTripAndReset(t *testing.T) {
+	var b diskCircuitBreaker
+	if b.isTripped() {
+		t.Fatal("breaker should start untripped")
+	}
+	if !b.trip() {
+		t.Fatal("first trip() should report it flipped the breaker")
+	}
+	if !b.isTripped() {
+		t.Fatal("breaker should report tripped after trip()")
+	}
+	if b.trip() {
+		t.Fatal("second trip() on an already-tripped breaker should report false")
+	}
+	b.reset()
+	if b.isTripped() {
+		t.Fatal("breaker should report untripped after reset()")
+	}
+	if !b.trip() {
+		t.Fatal("trip() after reset() should be able to flip the breaker again")
+	}
+}
+
+func TestResetBreakerClearsOrphanCounts(t *testing.T) {
+	p := newXLStorageDiskIDCheck(&xlStorage{})
+	atomic.StoreUint64(&p.apiOrphaned[storageMetricReadFile], diskMaxOrphanedCalls)
+	p.breaker.trip()
+
+	p.resetBreaker()
+
+	if p.breaker.isTripped() {
+		t.Fatal("resetBreaker should clear the tripped state")
+	}
+	if got := atomic.LoadUint64(&p.apiOrphaned[storageMetricReadFile]); got != 0 {
+		t.Fatalf("resetBreaker should zero apiOrphaned, got %d", got)
+	}
+}
+
+func TestRecordIfCancelledIgnoresFastCancellation(t *testing.T) {
+	p := newXLStorageDiskIDCheck(&xlStorage{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// start is "now", so this looks like a client that gave up on a call
+	// that hadn't even had time to run long, not a call stuck well past
+	// its own adaptive timeout.
+	p.recordIfCancelled(ctx, storageMetricReadFile, time.Now(), diskMaxTimeout)
+
+	if got := atomic.LoadUint64(&p.apiCancelled); got != 1 {
+		t.Fatalf("apiCancelled = %d, want 1", got)
+	}
+	if got := atomic.LoadUint64(&p.apiOrphaned[storageMetricReadFile]); got != 0 {
+		t.Fatalf("a cancellation within the adaptive timeout should not count as orphaned, got %d", got)
+	}
+}
+
+func TestRecordIfCancelledOrphansSlowCancellation(t *testing.T) {
+	p := newXLStorageDiskIDCheck(&xlStorage{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now().Add(-2 * diskMaxTimeout)
+	p.recordIfCancelled(ctx, storageMetricReadFile, start, diskMaxTimeout)
+
+	if got := atomic.LoadUint64(&p.apiOrphaned[storageMetricReadFile]); got != 1 {
+		t.Fatalf("a cancellation well past the adaptive timeout should count as orphaned, got %d", got)
+	}
+}
+
+func TestUpdateStorageMetricsTripsBreakerOnAdaptiveTimeout(t *testing.T) {
+	p := newXLStorageDiskIDCheck(&xlStorage{})
+	ctx := context.Background()
+
+	for i := 0; i < diskMaxConsecutiveTimeouts; i++ {
+		err := error(context.DeadlineExceeded)
+		p.updateStorageMetrics(ctx, storageMetricReadFile, time.Now(), &err)
+	}
+
+	if !p.breaker.isTripped() {
+		t.Fatal("diskMaxConsecutiveTimeouts adaptive-deadline timeouts in a row should trip the breaker")
+	}
+	if got := atomic.LoadUint64(&p.apiTimeouts[storageMetricReadFile]); got != diskMaxConsecutiveTimeouts {
+		t.Fatalf("apiTimeouts = %d, want %d", got, diskMaxConsecutiveTimeouts)
+	}
+}
+
+func TestUpdateStorageMetricsIgnoresInheritedDeadline(t *testing.T) {
+	p := newXLStorageDiskIDCheck(&xlStorage{})
+	// ctx's own deadline has already passed, so dctx (derived from ctx via
+	// deadlineCtx) is a pure pass-through per context.WithDeadline: any
+	// DeadlineExceeded seen below is the caller's own budget expiring, not
+	// this disk timing out.
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+
+	for i := 0; i < diskMaxConsecutiveTimeouts+2; i++ {
+		err := error(context.DeadlineExceeded)
+		p.updateStorageMetrics(ctx, storageMetricReadFile, time.Now(), &err)
+	}
+
+	if p.breaker.isTripped() {
+		t.Fatal("repeated timeouts driven by the caller's own inherited deadline should not trip the breaker")
+	}
+	if got := atomic.LoadUint64(&p.apiTimeouts[storageMetricReadFile]); got != 0 {
+		t.Fatalf("apiTimeouts = %d, want 0 for an inherited deadline", got)
+	}
+}
+
+func TestCancellableCallReturnsOnCompletion(t *testing.T) {
+	err := cancellableCall(context.Background(), func() error {
+		return errors.New("boom")
+	})
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("expected fn's own error to be returned, got %v", err)
+	}
+}
+
+func TestCancellableCallReturnsOnCancel(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cancellableCall(ctx, func() error {
+			close(started)
+			<-release // simulate a syscall that never notices ctx
+			return nil
+		})
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancellableCall did not return promptly after ctx was cancelled")
+	}
+	close(release) // let the orphaned goroutine finish so it doesn't leak past the test
+}
+
+type fakeReadCloser struct {
+	closed chan struct{}
+}
+
+func (f *fakeReadCloser) Read(p []byte) (int, error) {
+	<-f.closed
+	return 0, io.EOF
+}
+
+func (f *fakeReadCloser) Close() error {
+	select {
+	case <-f.closed:
+	default:
+		close(f.closed)
+	}
+	return nil
+}
+
+func TestWatchdogReadCloserClosesOnCancel(t *testing.T) {
+	rc := &fakeReadCloser{closed: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	wrapped := withReadCloserWatchdog(ctx, rc)
+	cancel()
+
+	select {
+	case <-rc.closed:
+	case <-time.After(time.Second):
+		t.Fatal("cancelling ctx did not close the underlying ReadCloser")
+	}
+
+	if err := wrapped.Close(); err != nil {
+		t.Fatalf("Close() after watchdog-triggered close returned %v", err)
+	}
+}
+
+func TestCancelReaderStopsAfterCancel(t *testing.T) {
+	r := bytes.NewReader([]byte("hello"))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cr := withReaderCancel(ctx, r)
+	buf := make([]byte, 2)
+	if _, err := cr.Read(buf); err != nil {
+		t.Fatalf("Read before cancel returned %v", err)
+	}
+
+	cancel()
+	if _, err := cr.Read(buf); err != context.Canceled {
+		t.Fatalf("Read after cancel = %v, want context.Canceled", err)
+	}
+}
+
+func TestWatchdogReadCloserCloseIsIdempotent(t *testing.T) {
+	rc := &fakeReadCloser{closed: make(chan struct{})}
+	ctx := context.Background()
+
+	wrapped := withReadCloserWatchdog(ctx, rc)
+	if err := wrapped.Close(); err != nil {
+		t.Fatalf("first Close() returned %v", err)
+	}
+	if err := wrapped.Close(); err != nil {
+		t.Fatalf("second Close() returned %v", err)
+	}
+}